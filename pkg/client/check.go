@@ -19,6 +19,7 @@ package client
 import (
 	"context"
 	"fmt"
+	"os"
 	"time"
 
 	"sigs.k8s.io/hydrophone/pkg/common"
@@ -39,14 +40,25 @@ type streamLogs struct {
 	doneCh chan bool
 }
 
-// PrintE2ELogs checks for Pod and start a go routine if new deployment added
-func (c *Client) PrintE2ELogs(ctx context.Context) error {
+// PrintE2ELogs checks for Pod and start a go routine if new deployment added.
+// Every line is printed to stdout as it arrives and also spooled to a
+// temporary file, whose path is returned so CollectResults can later
+// correlate the log with individual test results without holding the whole
+// run's output in memory. The caller is responsible for removing the
+// returned file once it's done with it.
+func (c *Client) PrintE2ELogs(ctx context.Context) (string, error) {
+	spool, err := os.CreateTemp("", "hydrophone-logs-")
+	if err != nil {
+		return "", fmt.Errorf("failed to create log spool file: %w", err)
+	}
+	defer spool.Close()
+
 	informerFactory := informers.NewSharedInformerFactory(c.ClientSet, 10*time.Second)
 
 	podInformer := informerFactory.Core().V1().Pods()
 
 	if _, err := podInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{}); err != nil {
-		return fmt.Errorf("failed to add event handler: %w", err)
+		return spool.Name(), fmt.Errorf("failed to add event handler: %w", err)
 	}
 
 	informerFactory.Start(wait.NeverStop)
@@ -55,7 +67,6 @@ func (c *Client) PrintE2ELogs(ctx context.Context) error {
 	for {
 		pod, _ := podInformer.Lister().Pods(viper.GetString("namespace")).Get(common.PodName)
 		if pod.Status.Phase == v1.PodRunning {
-			var err error
 			stream := streamLogs{
 				logCh:  make(chan string),
 				errCh:  make(chan error),
@@ -67,12 +78,14 @@ func (c *Client) PrintE2ELogs(ctx context.Context) error {
 		loop:
 			for {
 				select {
-				case err = <-stream.errCh:
-					log.Fatal(err)
+				case err := <-stream.errCh:
+					return spool.Name(), fmt.Errorf("log stream failed: %w", err)
 				case logStream := <-stream.logCh:
-					_, err = fmt.Print(logStream)
-					if err != nil {
-						log.Fatal(err)
+					if _, err := fmt.Print(logStream); err != nil {
+						return spool.Name(), fmt.Errorf("failed to write log output: %w", err)
+					}
+					if _, err := spool.WriteString(logStream); err != nil {
+						return spool.Name(), fmt.Errorf("failed to spool log output: %w", err)
 					}
 				case <-stream.doneCh:
 					break loop
@@ -82,7 +95,7 @@ func (c *Client) PrintE2ELogs(ctx context.Context) error {
 		}
 	}
 
-	return nil
+	return spool.Name(), nil
 }
 
 // FetchExitCode waits for pod to be in terminated state and get the exit code
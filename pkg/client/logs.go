@@ -0,0 +1,289 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/hydrophone/pkg/common"
+	"sigs.k8s.io/hydrophone/pkg/log"
+
+	"github.com/spf13/viper"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	initialReconnectBackoff = 1 * time.Second
+	maxReconnectBackoff     = 30 * time.Second
+
+	restartedContainerMarker = "--- conformance container restarted, resuming from previous container logs ---"
+)
+
+// getPodLogs streams the conformance container's logs into stream.logCh,
+// reconnecting with exponential backoff on transient failures and falling
+// back to the previous container's logs if it has restarted, then resuming
+// the restarted container's live logs once those are drained. It only ever
+// reports a non-recoverable error (or ctx.Err()) on stream.errCh; callers no
+// longer need to treat every errCh message as fatal.
+func getPodLogs(ctx context.Context, clientset kubernetes.Interface, stream streamLogs) {
+	defer close(stream.doneCh)
+
+	namespace := viper.GetString("namespace")
+	reconnectTimeout := viper.GetDuration("log-reconnect-timeout")
+
+	var deadline time.Time
+	if reconnectTimeout > 0 {
+		deadline = time.Now().Add(reconnectTimeout)
+	}
+
+	var sinceTime *metav1.Time
+	var dedup dedupState
+	usePrevious := false
+	backoff := initialReconnectBackoff
+
+	// seenRestarts is the container restart count we've already reacted to,
+	// so a container that restarts once doesn't look "restarted" forever.
+	seenRestarts, _ := containerRestartCount(ctx, clientset, namespace)
+
+	for {
+		opts := &v1.PodLogOptions{
+			Container:  common.ConformanceContainer,
+			Follow:     true,
+			Previous:   usePrevious,
+			Timestamps: true,
+			SinceTime:  sinceTime,
+		}
+
+		err := streamPodLogsOnce(ctx, clientset, namespace, opts, stream, &sinceTime, &dedup)
+
+		if err == nil {
+			// A clean EOF is how the kubelet ends the stream both when the
+			// container exits for good and when it crashes and restarts, so
+			// a restart has to be checked for here too, not only on
+			// transient errors below.
+			if usePrevious {
+				// The previous container's leftover logs are drained; go
+				// back to following the restarted container's live output.
+				usePrevious = false
+				sinceTime = nil
+				dedup = dedupState{}
+				backoff = initialReconnectBackoff
+				continue
+			}
+
+			if switchToPrevious(ctx, clientset, namespace, &seenRestarts, stream) {
+				// Keep sinceTime and dedup: the previous container is the one
+				// just read, so only its lines not yet forwarded are wanted.
+				usePrevious = true
+				backoff = initialReconnectBackoff
+				continue
+			}
+
+			return
+		}
+
+		if ctx.Err() != nil {
+			stream.errCh <- ctx.Err()
+			return
+		}
+
+		if !isRetryableLogError(err) {
+			stream.errCh <- err
+			return
+		}
+
+		if !deadline.IsZero() && time.Now().After(deadline) {
+			stream.errCh <- fmt.Errorf("giving up reconnecting to log stream after %s: %w", reconnectTimeout, err)
+			return
+		}
+
+		if !usePrevious && switchToPrevious(ctx, clientset, namespace, &seenRestarts, stream) {
+			usePrevious = true
+		}
+
+		log.Printf("log stream interrupted (%v), reconnecting in %s...", err, backoff)
+
+		select {
+		case <-ctx.Done():
+			stream.errCh <- ctx.Err()
+			return
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > maxReconnectBackoff {
+			backoff = maxReconnectBackoff
+		}
+	}
+}
+
+// switchToPrevious reports whether the conformance container has restarted
+// since *seenRestarts, and if so updates *seenRestarts and emits
+// restartedContainerMarker so the caller switches to Previous: true.
+func switchToPrevious(ctx context.Context, clientset kubernetes.Interface, namespace string, seenRestarts *int32, stream streamLogs) bool {
+	restarts, err := containerRestartCount(ctx, clientset, namespace)
+	if err != nil || restarts <= *seenRestarts {
+		return false
+	}
+
+	*seenRestarts = restarts
+	stream.logCh <- restartedContainerMarker + "\n"
+
+	return true
+}
+
+// dedupState tracks which exact lines have already been forwarded during the
+// last whole second covered by *sinceTime. The Kubernetes logs API only
+// accepts SinceTime at whole-second (RFC3339) precision, coarser than the
+// nanosecond precision parseLogTimestamp extracts, so a reconnect landing
+// mid-second re-streams every line from that second; dedupState lets
+// streamPodLogsOnce recognize and drop the lines it already forwarded rather
+// than forwarding them twice.
+type dedupState struct {
+	second string
+	seen   map[string]struct{}
+}
+
+// streamPodLogsOnce opens a single log stream and forwards every line to
+// stream.logCh, updating *sinceTime as lines arrive so a reconnect can
+// resume without duplicating output, and using dedup to suppress the exact
+// duplicate lines a reconnect's whole-second SinceTime can reintroduce (see
+// dedupState). A nil return means the stream ended because the container
+// itself finished.
+func streamPodLogsOnce(ctx context.Context, clientset kubernetes.Interface, namespace string, opts *v1.PodLogOptions, stream streamLogs, sinceTime **metav1.Time, dedup *dedupState) error {
+	req := clientset.CoreV1().Pods(namespace).GetLogs(common.PodName, opts)
+
+	readCloser, err := req.Stream(ctx)
+	if err != nil {
+		return err
+	}
+	defer readCloser.Close()
+
+	reader := bufio.NewReader(readCloser)
+
+	for {
+		line, err := reader.ReadString('\n')
+		if len(line) > 0 {
+			forward := true
+
+			if ts, ok := parseLogTimestamp(line); ok {
+				second := ts.UTC().Truncate(time.Second).Format(time.RFC3339)
+				if dedup.second != second {
+					dedup.second = second
+					dedup.seen = make(map[string]struct{})
+				}
+
+				if _, already := dedup.seen[line]; already {
+					forward = false
+				} else {
+					dedup.seen[line] = struct{}{}
+				}
+
+				*sinceTime = ts
+			}
+
+			if forward {
+				stream.logCh <- line
+			}
+		}
+
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+
+			return err
+		}
+	}
+}
+
+// parseLogTimestamp extracts the RFC3339Nano timestamp Kubernetes prefixes
+// each line with when PodLogOptions.Timestamps is true.
+func parseLogTimestamp(line string) (*metav1.Time, bool) {
+	fields := strings.SplitN(line, " ", 2)
+	if len(fields) == 0 {
+		return nil, false
+	}
+
+	ts, err := time.Parse(time.RFC3339Nano, fields[0])
+	if err != nil {
+		return nil, false
+	}
+
+	metaTime := metav1.NewTime(ts)
+
+	return &metaTime, true
+}
+
+// containerRestartCount returns the conformance container's current restart
+// count, used to detect when it has crashed and been restarted since the
+// last time it was checked.
+func containerRestartCount(ctx context.Context, clientset kubernetes.Interface, namespace string) (int32, error) {
+	pod, err := clientset.CoreV1().Pods(namespace).Get(ctx, common.PodName, metav1.GetOptions{})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		if containerStatus.Name == common.ConformanceContainer {
+			return containerStatus.RestartCount, nil
+		}
+	}
+
+	return 0, nil
+}
+
+// isRetryableLogError reports whether err is a transient condition worth
+// reconnecting for, such as a dropped connection or a 5xx from the
+// API server, as opposed to a permanent failure like the pod not existing.
+func isRetryableLogError(err error) bool {
+	if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+		return true
+	}
+
+	if errors.Is(err, net.ErrClosed) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	if apierrors.IsInternalError(err) || apierrors.IsServiceUnavailable(err) || apierrors.IsTimeout(err) || apierrors.IsTooManyRequests(err) {
+		return true
+	}
+
+	if statusErr, ok := err.(apierrors.APIStatus); ok {
+		code := statusErr.Status().Code
+		if code >= 500 && code < 600 {
+			return true
+		}
+	}
+
+	return strings.Contains(err.Error(), "connection reset by peer")
+}
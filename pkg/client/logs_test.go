@@ -0,0 +1,124 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"sigs.k8s.io/hydrophone/pkg/common"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestIsRetryableLogError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"EOF", io.EOF, true},
+		{"unexpected EOF", io.ErrUnexpectedEOF, true},
+		{"closed connection", net.ErrClosed, true},
+		{"connection reset", errors.New("read tcp: connection reset by peer"), true},
+		{"internal server error", apierrors.NewInternalError(errors.New("boom")), true},
+		{"not found", apierrors.NewNotFound(schema.GroupResource{Resource: "pods"}, "pod"), false},
+	}
+
+	for _, tt := range tests {
+		if got := isRetryableLogError(tt.err); got != tt.want {
+			t.Errorf("isRetryableLogError(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestParseLogTimestamp(t *testing.T) {
+	line := "2023-01-02T03:04:05.000000000Z some log line\n"
+
+	ts, ok := parseLogTimestamp(line)
+	if !ok {
+		t.Fatal("expected a timestamp to be parsed")
+	}
+
+	want := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	if !ts.Time.Equal(want) {
+		t.Errorf("parsed timestamp = %v, want %v", ts.Time, want)
+	}
+
+	if _, ok := parseLogTimestamp("not a timestamp\n"); ok {
+		t.Error("expected no timestamp to be parsed from a line without one")
+	}
+}
+
+func TestContainerRestartCount(t *testing.T) {
+	clientset := fake.NewSimpleClientset(&v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: common.PodName, Namespace: "conformance"},
+		Status: v1.PodStatus{
+			ContainerStatuses: []v1.ContainerStatus{
+				{Name: common.ConformanceContainer, RestartCount: 2},
+			},
+		},
+	})
+
+	got, err := containerRestartCount(context.Background(), clientset, "conformance")
+	if err != nil {
+		t.Fatalf("containerRestartCount returned an error: %v", err)
+	}
+	if got != 2 {
+		t.Errorf("containerRestartCount = %d, want 2", got)
+	}
+}
+
+func TestSwitchToPrevious(t *testing.T) {
+	clientset := fake.NewSimpleClientset(&v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: common.PodName, Namespace: "conformance"},
+		Status: v1.PodStatus{
+			ContainerStatuses: []v1.ContainerStatus{
+				{Name: common.ConformanceContainer, RestartCount: 1},
+			},
+		},
+	})
+
+	stream := streamLogs{logCh: make(chan string, 1)}
+	seenRestarts := int32(0)
+
+	if !switchToPrevious(context.Background(), clientset, "conformance", &seenRestarts, stream) {
+		t.Fatal("expected a restart to be detected")
+	}
+	if seenRestarts != 1 {
+		t.Errorf("seenRestarts = %d, want 1", seenRestarts)
+	}
+
+	select {
+	case <-stream.logCh:
+	default:
+		t.Error("expected a restart marker to be emitted on stream.logCh")
+	}
+
+	// A second call with the same restart count should not fire again.
+	if switchToPrevious(context.Background(), clientset, "conformance", &seenRestarts, stream) {
+		t.Error("did not expect a second restart to be detected without a new RestartCount")
+	}
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/hydrophone/pkg/common"
+	"sigs.k8s.io/hydrophone/pkg/results"
+
+	"github.com/spf13/viper"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// resultsDir is where the conformance image writes its Ginkgo JUnit report.
+const resultsDir = "/tmp/results"
+
+// CollectResults copies the contents of resultsDir out of the conformance
+// pod, parses the JUnit report it contains, correlates it with the spans
+// extracted from the streamed logs spooled at logFile (as returned by
+// PrintE2ELogs) and renders the requested JSON/JUnit reports plus the
+// end-of-run summary. It should be called after FetchExitCode, once the
+// conformance container has terminated. An empty logFile skips correlation.
+func (c *Client) CollectResults(ctx context.Context, logFile string) error {
+	localDir, err := os.MkdirTemp("", "hydrophone-results-")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary results directory: %w", err)
+	}
+	defer os.RemoveAll(localDir)
+
+	if err := c.copyFromPod(ctx, resultsDir, localDir); err != nil {
+		return fmt.Errorf("failed to copy results out of pod: %w", err)
+	}
+
+	report, err := results.ParseJUnitFile(filepath.Join(localDir, "junit_01.xml"))
+	if err != nil {
+		return fmt.Errorf("failed to parse conformance results: %w", err)
+	}
+	report.GeneratedAt = time.Now()
+
+	if logFile != "" {
+		if err := results.CorrelateLogFile(report, logFile); err != nil {
+			return fmt.Errorf("failed to correlate streamed logs: %w", err)
+		}
+	}
+
+	outputDir := viper.GetString("output-dir")
+
+	if junitFile := viper.GetString("output-junit"); junitFile != "" {
+		if err := results.WriteJUnitFile(report, filepath.Join(outputDir, junitFile)); err != nil {
+			return err
+		}
+	}
+
+	if jsonFile := viper.GetString("output-json"); jsonFile != "" {
+		if err := results.WriteJSONReport(report, filepath.Join(outputDir, jsonFile)); err != nil {
+			return err
+		}
+	}
+
+	results.PrintSummary(report, viper.GetString("summary"))
+
+	return nil
+}
+
+// copyFromPod streams remoteDir out of the conformance container as a tar
+// archive over exec and extracts it into localDir, mirroring what
+// `kubectl cp` does under the hood.
+func (c *Client) copyFromPod(ctx context.Context, remoteDir, localDir string) error {
+	req := c.ClientSet.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Name(common.PodName).
+		Namespace(viper.GetString("namespace")).
+		SubResource("exec")
+
+	req.VersionedParams(&v1.PodExecOptions{
+		Container: common.ConformanceContainer,
+		Command:   []string{"tar", "cf", "-", "-C", filepath.Dir(remoteDir), filepath.Base(remoteDir)},
+		Stdout:    true,
+		Stderr:    true,
+	}, scheme.ParameterCodec)
+
+	executor, err := remotecommand.NewSPDYExecutor(c.RestConfig, "POST", req.URL())
+	if err != nil {
+		return fmt.Errorf("failed to create exec executor: %w", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if err := executor.StreamWithContext(ctx, remotecommand.StreamOptions{
+		Stdout: &stdout,
+		Stderr: &stderr,
+	}); err != nil {
+		return fmt.Errorf("failed to exec into pod (stderr: %s): %w", stderr.String(), err)
+	}
+
+	return extractTar(&stdout, localDir)
+}
+
+// extractTar writes the contents of the tar stream r into destDir, stripping
+// only the single top-level directory the archive is expected to contain
+// (e.g. "results/junit_01.xml" becomes destDir+"/junit_01.xml"), while
+// preserving any deeper subdirectories so same-named files in different
+// subdirectories don't collide.
+func extractTar(r io.Reader, destDir string) error {
+	tr := tar.NewReader(r)
+
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read tar stream: %w", err)
+		}
+
+		if header.Typeflag == tar.TypeDir {
+			continue
+		}
+
+		name := stripTopLevelDir(header.Name)
+		target := filepath.Join(destDir, name)
+
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return fmt.Errorf("failed to create directory for %q: %w", target, err)
+		}
+
+		file, err := os.Create(target)
+		if err != nil {
+			return fmt.Errorf("failed to create %q: %w", target, err)
+		}
+
+		if _, err := io.Copy(file, tr); err != nil { //nolint:gosec // results archive is produced by our own conformance image
+			file.Close()
+			return fmt.Errorf("failed to write %q: %w", target, err)
+		}
+
+		if err := file.Close(); err != nil {
+			return err
+		}
+	}
+}
+
+// stripTopLevelDir removes the first path component of a tar entry's name,
+// leaving any deeper path intact. If the entry has no subdirectory, the bare
+// base name is returned.
+func stripTopLevelDir(name string) string {
+	parts := strings.SplitN(filepath.ToSlash(name), "/", 2)
+	if len(parts) < 2 {
+		return filepath.Base(name)
+	}
+
+	return filepath.FromSlash(parts[1])
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package results
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ginkgoSeparator is the separator line Ginkgo prints around each spec when
+// run with -v, e.g.:
+//
+//	------------------------------
+//	[sig-api-machinery] ... should do the thing
+//	  test/e2e/foo.go:123
+//	------------------------------
+const ginkgoSeparator = "------------------------------"
+
+// maxLogLine bounds how long a single spooled log line is allowed to be.
+const maxLogLine = 10 * 1024 * 1024
+
+// CorrelateLogs splits the raw streamed pod log into per-test spans and
+// attaches each span to the matching TestResult in report, keyed by name.
+// Lines that cannot be attributed to a specific spec (suite setup/teardown
+// noise) are dropped.
+func CorrelateLogs(report *Report, logLines []string) {
+	correlate(report, bufio.NewScanner(strings.NewReader(strings.Join(logLines, "\n"))))
+}
+
+// CorrelateLogFile is like CorrelateLogs, but reads the raw log from the
+// file at path (as spooled by PrintE2ELogs) one line at a time instead of
+// loading the whole file at once. The spans of every spec found in the log
+// are still collected in memory before they are attached to report.
+func CorrelateLogFile(report *Report, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open spooled log %q: %w", path, err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLogLine)
+
+	correlate(report, scanner)
+
+	return nil
+}
+
+// correlate splits the lines read from scanner into per-test spans and
+// attaches each span to the matching TestResult in report, keyed by name.
+func correlate(report *Report, scanner *bufio.Scanner) {
+	spans := splitIntoSpans(scanner)
+
+	for i := range report.Tests {
+		if span, ok := spans[report.Tests[i].Name]; ok {
+			report.Tests[i].Log = span
+		}
+	}
+}
+
+// splitIntoSpans groups lines found between two ginkgoSeparator lines and
+// indexes them by the spec name found on the first line of the span.
+func splitIntoSpans(scanner *bufio.Scanner) map[string]string {
+	spans := make(map[string]string)
+
+	var current strings.Builder
+	var name string
+	inSpan := false
+
+	flush := func() {
+		if name != "" {
+			spans[name] = strings.TrimSpace(current.String())
+		}
+		current.Reset()
+		name = ""
+	}
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if strings.TrimSpace(line) == ginkgoSeparator {
+			if inSpan {
+				flush()
+				inSpan = false
+			} else {
+				inSpan = true
+			}
+			continue
+		}
+
+		if !inSpan {
+			continue
+		}
+
+		if name == "" {
+			name = strings.TrimSpace(line)
+			continue
+		}
+
+		current.WriteString(line)
+		current.WriteString("\n")
+	}
+
+	return spans
+}
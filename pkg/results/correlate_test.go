@@ -0,0 +1,91 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package results
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func sampleLog() []string {
+	return []string{
+		"suite setup noise",
+		ginkgoSeparator,
+		"[sig-network] Services should work [Conformance]",
+		"some log line",
+		"another log line",
+		ginkgoSeparator,
+		ginkgoSeparator,
+		"[sig-storage] Volumes should fail [Conformance]",
+		"boom",
+		ginkgoSeparator,
+		"suite teardown noise",
+	}
+}
+
+func TestCorrelateLogs(t *testing.T) {
+	report := &Report{
+		Tests: []TestResult{
+			{Name: "[sig-network] Services should work [Conformance]"},
+			{Name: "[sig-storage] Volumes should fail [Conformance]"},
+			{Name: "[sig-apps] Deployments should skip"},
+		},
+	}
+
+	CorrelateLogs(report, sampleLog())
+
+	if got, want := report.Tests[0].Log, "some log line\nanother log line"; got != want {
+		t.Errorf("Tests[0].Log = %q, want %q", got, want)
+	}
+	if got, want := report.Tests[1].Log, "boom"; got != want {
+		t.Errorf("Tests[1].Log = %q, want %q", got, want)
+	}
+	if report.Tests[2].Log != "" {
+		t.Errorf("Tests[2].Log = %q, want empty (no span for this test)", report.Tests[2].Log)
+	}
+}
+
+func TestCorrelateLogFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "e2e.log")
+	if err := os.WriteFile(path, []byte(strings.Join(sampleLog(), "\n")), 0o644); err != nil {
+		t.Fatalf("failed to write sample log file: %v", err)
+	}
+
+	report := &Report{
+		Tests: []TestResult{
+			{Name: "[sig-network] Services should work [Conformance]"},
+		},
+	}
+
+	if err := CorrelateLogFile(report, path); err != nil {
+		t.Fatalf("CorrelateLogFile returned an error: %v", err)
+	}
+
+	if got, want := report.Tests[0].Log, "some log line\nanother log line"; got != want {
+		t.Errorf("Tests[0].Log = %q, want %q", got, want)
+	}
+}
+
+func TestCorrelateLogFileMissing(t *testing.T) {
+	report := &Report{}
+
+	if err := CorrelateLogFile(report, filepath.Join(t.TempDir(), "does-not-exist.log")); err == nil {
+		t.Fatal("expected an error for a missing log file, got nil")
+	}
+}
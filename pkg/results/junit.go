@@ -0,0 +1,160 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package results
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"regexp"
+	"time"
+)
+
+// labelPattern matches the bracketed labels Kubernetes e2e test names carry,
+// e.g. "[sig-network] ... [Conformance]" -> "sig-network", "Conformance".
+var labelPattern = regexp.MustCompile(`\[([^\[\]]+)\]`)
+
+// extractLabels pulls every bracketed label out of a Ginkgo spec name.
+func extractLabels(name string) []string {
+	matches := labelPattern.FindAllStringSubmatch(name, -1)
+	if len(matches) == 0 {
+		return nil
+	}
+
+	labels := make([]string, 0, len(matches))
+	for _, match := range matches {
+		labels = append(labels, match[1])
+	}
+
+	return labels
+}
+
+// junitTestSuites mirrors the structure Ginkgo writes to junit_01.xml.
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      float64         `xml:"time,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Time      float64       `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure"`
+	Skipped   *struct{}     `xml:"skipped"`
+	SystemOut string        `xml:"system-out"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// ParseJUnitFile reads the Ginkgo JUnit report at path and turns it into a
+// normalized Report. The generated timestamp is left zero; callers should
+// set it once the report is finalized.
+func ParseJUnitFile(path string) (*Report, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read junit report: %w", err)
+	}
+
+	var parsed junitTestSuites
+	if err := xml.Unmarshal(data, &parsed); err != nil {
+		return nil, fmt.Errorf("failed to parse junit report: %w", err)
+	}
+
+	report := &Report{}
+
+	for _, suite := range parsed.Suites {
+		for _, tc := range suite.TestCases {
+			test := TestResult{
+				Name:     tc.Name,
+				Status:   StatusPassed,
+				Duration: time.Duration(tc.Time * float64(time.Second)),
+				Log:      tc.SystemOut,
+				Labels:   extractLabels(tc.Name),
+			}
+
+			switch {
+			case tc.Failure != nil:
+				test.Status = StatusFailed
+				test.FailureMessage = tc.Failure.Message
+				test.Stack = tc.Failure.Text
+			case tc.Skipped != nil:
+				test.Status = StatusSkipped
+			}
+
+			report.add(test)
+		}
+	}
+
+	return report, nil
+}
+
+// WriteJUnitFile renders report back into a single merged JUnit XML document
+// at path, so downstream tooling only has to deal with one file regardless
+// of how many suites the conformance image produced.
+func WriteJUnitFile(report *Report, path string) error {
+	suite := junitTestSuite{
+		Name:     "Kubernetes e2e suite",
+		Tests:    report.Total,
+		Failures: report.Failed,
+		Skipped:  report.Skipped,
+	}
+
+	for _, test := range report.Tests {
+		tc := junitTestCase{
+			Name:      test.Name,
+			Classname: "Kubernetes e2e suite",
+			Time:      test.Duration.Seconds(),
+			SystemOut: test.Log,
+		}
+
+		suite.Time += tc.Time
+
+		switch test.Status {
+		case StatusFailed:
+			tc.Failure = &junitFailure{Message: test.FailureMessage, Text: test.Stack}
+		case StatusSkipped:
+			tc.Skipped = &struct{}{}
+		}
+
+		suite.TestCases = append(suite.TestCases, tc)
+	}
+
+	out := junitTestSuites{Suites: []junitTestSuite{suite}}
+
+	data, err := xml.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to render junit report: %w", err)
+	}
+
+	if err := os.WriteFile(path, append([]byte(xml.Header), data...), 0o644); err != nil {
+		return fmt.Errorf("failed to write junit report to %q: %w", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package results
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const sampleJUnit = `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite name="Kubernetes e2e suite" tests="3" failures="1" skipped="1" time="4.5">
+    <testcase name="[sig-network] Services should work [Conformance]" classname="Kubernetes e2e suite" time="1.5">
+      <system-out>ok</system-out>
+    </testcase>
+    <testcase name="[sig-storage] Volumes should fail [Conformance]" classname="Kubernetes e2e suite" time="2">
+      <failure message="unexpected error">boom</failure>
+    </testcase>
+    <testcase name="[sig-apps] Deployments should skip" classname="Kubernetes e2e suite" time="1">
+      <skipped/>
+    </testcase>
+  </testsuite>
+</testsuites>
+`
+
+func writeJUnitFile(t *testing.T, contents string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "junit_01.xml")
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("failed to write sample junit file: %v", err)
+	}
+
+	return path
+}
+
+func TestParseJUnitFile(t *testing.T) {
+	path := writeJUnitFile(t, sampleJUnit)
+
+	report, err := ParseJUnitFile(path)
+	if err != nil {
+		t.Fatalf("ParseJUnitFile returned an error: %v", err)
+	}
+
+	if report.Total != 3 || report.Passed != 1 || report.Failed != 1 || report.Skipped != 1 {
+		t.Fatalf("unexpected report counters: %+v", report)
+	}
+
+	passed := report.Tests[0]
+	if passed.Status != StatusPassed {
+		t.Errorf("expected first test to have passed, got %s", passed.Status)
+	}
+	if want := []string{"sig-network", "Conformance"}; !reflect.DeepEqual(passed.Labels, want) {
+		t.Errorf("Labels = %v, want %v", passed.Labels, want)
+	}
+
+	failed := report.Tests[1]
+	if failed.Status != StatusFailed {
+		t.Errorf("expected second test to have failed, got %s", failed.Status)
+	}
+	if failed.FailureMessage != "unexpected error" {
+		t.Errorf("FailureMessage = %q, want %q", failed.FailureMessage, "unexpected error")
+	}
+	if failed.Stack != "boom" {
+		t.Errorf("Stack = %q, want %q", failed.Stack, "boom")
+	}
+
+	skipped := report.Tests[2]
+	if skipped.Status != StatusSkipped {
+		t.Errorf("expected third test to have been skipped, got %s", skipped.Status)
+	}
+	if want := []string{"sig-apps"}; !reflect.DeepEqual(skipped.Labels, want) {
+		t.Errorf("Labels = %v, want %v", skipped.Labels, want)
+	}
+}
+
+func TestExtractLabels(t *testing.T) {
+	tests := []struct {
+		name string
+		want []string
+	}{
+		{"[sig-network] Services should work [Conformance]", []string{"sig-network", "Conformance"}},
+		{"Deployments should skip", nil},
+		{"[Feature:Foo]", []string{"Feature:Foo"}},
+	}
+
+	for _, tt := range tests {
+		if got := extractLabels(tt.name); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("extractLabels(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
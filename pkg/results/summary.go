@@ -0,0 +1,63 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package results
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/hydrophone/pkg/log"
+)
+
+// Summary verbosity levels accepted by the --summary flag.
+const (
+	SummaryNone  = "none"
+	SummaryShort = "short"
+	SummaryFull  = "full"
+)
+
+// PrintSummary prints a human readable summary of report to stdout. mode
+// controls how much detail is shown and should be one of SummaryNone,
+// SummaryShort or SummaryFull; an empty string is treated as SummaryShort.
+func PrintSummary(report *Report, mode string) {
+	if mode == "" {
+		mode = SummaryShort
+	}
+
+	if mode == SummaryNone {
+		return
+	}
+
+	log.Printf("Conformance results: %d passed, %d failed, %d skipped (%d total)",
+		report.Passed, report.Failed, report.Skipped, report.Total)
+
+	if mode != SummaryFull {
+		return
+	}
+
+	for _, test := range report.Tests {
+		if test.Status != StatusFailed {
+			continue
+		}
+
+		log.Printf("FAILED: %s", test.Name)
+		if test.FailureMessage != "" {
+			log.Printf("  %s", test.FailureMessage)
+		}
+	}
+
+	fmt.Println()
+}
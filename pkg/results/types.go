@@ -0,0 +1,67 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package results parses the Ginkgo JUnit report produced by the conformance
+// image and turns it into normalized reports that are easier to consume from
+// CI systems and dashboards.
+package results
+
+import "time"
+
+// Status is the outcome of a single conformance test.
+type Status string
+
+const (
+	StatusPassed  Status = "passed"
+	StatusFailed  Status = "failed"
+	StatusSkipped Status = "skipped"
+)
+
+// TestResult is the normalized representation of a single Ginkgo spec.
+type TestResult struct {
+	Name           string        `json:"name"`
+	Status         Status        `json:"status"`
+	Duration       time.Duration `json:"duration"`
+	FailureMessage string        `json:"failureMessage,omitempty"`
+	Stack          string        `json:"stack,omitempty"`
+	Labels         []string      `json:"labels,omitempty"`
+	Log            string        `json:"log,omitempty"`
+}
+
+// Report is the normalized result of a full conformance run.
+type Report struct {
+	GeneratedAt time.Time    `json:"generatedAt"`
+	Total       int          `json:"total"`
+	Passed      int          `json:"passed"`
+	Failed      int          `json:"failed"`
+	Skipped     int          `json:"skipped"`
+	Tests       []TestResult `json:"tests"`
+}
+
+// add updates the report's summary counters for a newly appended test.
+func (r *Report) add(test TestResult) {
+	r.Tests = append(r.Tests, test)
+	r.Total++
+
+	switch test.Status {
+	case StatusPassed:
+		r.Passed++
+	case StatusFailed:
+		r.Failed++
+	case StatusSkipped:
+		r.Skipped++
+	}
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package matrix runs the conformance suite against several clusters
+// concurrently and aggregates their results into a single pass/fail matrix,
+// the natural extension for fleet operators who would otherwise script
+// hydrophone in a loop.
+package matrix
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"sigs.k8s.io/hydrophone/pkg/results"
+)
+
+// Target is a single cluster to run the conformance suite against as part
+// of a matrix run.
+type Target struct {
+	// Name identifies the target in status output and the results matrix.
+	// It is derived from the kubeconfig file's parent directory and base
+	// name (see targetName).
+	Name string
+	// Kubeconfig is the path to this target's kubeconfig file.
+	Kubeconfig string
+	// Namespace is the namespace this target's conformance pod runs in. It
+	// suffixes the configured namespace with Name so concurrent targets
+	// never collide.
+	Namespace string
+}
+
+// NewTargets builds one Target per kubeconfig path, deriving each target's
+// name from its parent directory and file name and suffixing baseNamespace
+// with that name so every target runs in its own isolated namespace.
+// Callers are expected to have already rejected kubeconfigs that would
+// derive colliding names (see Configuration.resolveMatrixKubeconfigs); any
+// that still collide are disambiguated with a numeric suffix so two targets
+// never silently share a name.
+func NewTargets(kubeconfigs []string, baseNamespace string) []Target {
+	targets := make([]Target, len(kubeconfigs))
+	seen := make(map[string]int, len(kubeconfigs))
+
+	for i, kubeconfig := range kubeconfigs {
+		name := targetName(kubeconfig)
+
+		if seen[name]++; seen[name] > 1 {
+			name = fmt.Sprintf("%s-%d", name, seen[name])
+		}
+
+		targets[i] = Target{
+			Name:       name,
+			Kubeconfig: kubeconfig,
+			Namespace:  fmt.Sprintf("%s-%s", baseNamespace, name),
+		}
+	}
+
+	return targets
+}
+
+// targetName derives a target's display name from its kubeconfig path,
+// combining the parent directory and file name (without extension) so that
+// sibling kubeconfigs sharing a file name, e.g. clusters/east/config and
+// clusters/west/config, still get distinct names.
+func targetName(kubeconfig string) string {
+	base := strings.TrimSuffix(filepath.Base(kubeconfig), filepath.Ext(kubeconfig))
+	dir := filepath.Base(filepath.Dir(kubeconfig))
+
+	if dir == "" || dir == "." || dir == string(filepath.Separator) {
+		return base
+	}
+
+	return dir + "-" + base
+}
+
+// RunFunc executes the conformance suite against a single target and
+// returns its normalized results along with the conformance container's
+// exit code.
+type RunFunc func(ctx context.Context, target Target) (*results.Report, int, error)
+
+// ClusterResult is the outcome of running the conformance suite against a
+// single matrix target.
+type ClusterResult struct {
+	Target   Target
+	Report   *results.Report
+	ExitCode int
+	Err      error
+}
+
+// Options configures a matrix run.
+type Options struct {
+	// MaxConcurrent bounds how many targets run at the same time. Values
+	// <= 0 are treated as 1.
+	MaxConcurrent int
+}
+
+// Run executes runFunc against every target, bounded by opts.MaxConcurrent
+// concurrent runs, and returns one ClusterResult per target in the same
+// order as targets. A failure against one target does not stop the others
+// from running; it is recorded in that target's ClusterResult.Err instead.
+func Run(ctx context.Context, targets []Target, opts Options, runFunc RunFunc) []ClusterResult {
+	maxConcurrent := opts.MaxConcurrent
+	if maxConcurrent <= 0 {
+		maxConcurrent = 1
+	}
+
+	outcomes := make([]ClusterResult, len(targets))
+	sem := make(chan struct{}, maxConcurrent)
+
+	var wg sync.WaitGroup
+	for i, target := range targets {
+		wg.Add(1)
+
+		go func(i int, target Target) {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			report, exitCode, err := runFunc(ctx, target)
+			outcomes[i] = ClusterResult{
+				Target:   target,
+				Report:   report,
+				ExitCode: exitCode,
+				Err:      err,
+			}
+		}(i, target)
+	}
+
+	wg.Wait()
+
+	return outcomes
+}
+
+// AggregateExitCode returns a single process exit code summarizing outcomes:
+// 0 if every target passed, or the first non-zero exit code encountered
+// (1, if a target errored before one was produced) otherwise.
+func AggregateExitCode(outcomes []ClusterResult) int {
+	for _, outcome := range outcomes {
+		if outcome.Err != nil {
+			return 1
+		}
+
+		if outcome.ExitCode != 0 {
+			return outcome.ExitCode
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package matrix
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync/atomic"
+	"testing"
+
+	"sigs.k8s.io/hydrophone/pkg/results"
+)
+
+func TestNewTargetsDerivesDistinctNames(t *testing.T) {
+	targets := NewTargets([]string{
+		"clusters/east/config",
+		"clusters/west/config",
+	}, "conformance")
+
+	if targets[0].Name == targets[1].Name {
+		t.Fatalf("expected distinct names for same-named kubeconfigs in different directories, got %q and %q", targets[0].Name, targets[1].Name)
+	}
+	if targets[0].Namespace == targets[1].Namespace {
+		t.Fatalf("expected distinct namespaces, got %q for both", targets[0].Namespace)
+	}
+}
+
+func TestNewTargetsDedupesIdenticalNames(t *testing.T) {
+	targets := NewTargets([]string{
+		"clusters/east/config.yaml",
+		"clusters/east/config.yaml",
+	}, "conformance")
+
+	if targets[0].Name == targets[1].Name {
+		t.Fatalf("expected NewTargets to disambiguate colliding names, got %q for both", targets[0].Name)
+	}
+}
+
+func TestRunBoundsConcurrencyAndCollectsAllResults(t *testing.T) {
+	targets := []Target{{Name: "a"}, {Name: "b"}, {Name: "c"}}
+
+	var inFlight, maxInFlight int32
+
+	runFunc := func(ctx context.Context, target Target) (*results.Report, int, error) {
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+		defer atomic.AddInt32(&inFlight, -1)
+
+		if target.Name == "b" {
+			return nil, 0, errors.New("boom")
+		}
+
+		return &results.Report{Passed: 1}, 0, nil
+	}
+
+	outcomes := Run(context.Background(), targets, Options{MaxConcurrent: 2}, runFunc)
+
+	if len(outcomes) != 3 {
+		t.Fatalf("got %d outcomes, want 3", len(outcomes))
+	}
+	if atomic.LoadInt32(&maxInFlight) > 2 {
+		t.Errorf("observed %d concurrent runs, want at most 2 (MaxConcurrent)", maxInFlight)
+	}
+	if outcomes[1].Err == nil {
+		t.Errorf("expected outcome for target %q to carry its error", targets[1].Name)
+	}
+	if outcomes[0].Report == nil || outcomes[2].Report == nil {
+		t.Errorf("expected successful targets to carry a report")
+	}
+}
+
+func TestAggregateExitCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		outcomes []ClusterResult
+		want     int
+	}{
+		{"all passed", []ClusterResult{{ExitCode: 0}, {ExitCode: 0}}, 0},
+		{"one failed", []ClusterResult{{ExitCode: 0}, {ExitCode: 3}}, 3},
+		{"one errored", []ClusterResult{{ExitCode: 0}, {Err: errors.New("boom")}}, 1},
+	}
+
+	for _, tt := range tests {
+		if got := AggregateExitCode(tt.outcomes); got != tt.want {
+			t.Errorf("%s: AggregateExitCode = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBuildMatrix(t *testing.T) {
+	outcomes := []ClusterResult{
+		{
+			Target: Target{Name: "east"},
+			Report: &results.Report{Tests: []results.TestResult{
+				{Name: "t1", Status: results.StatusPassed},
+			}},
+		},
+		{
+			Target: Target{Name: "west"},
+			Report: &results.Report{Tests: []results.TestResult{
+				{Name: "t1", Status: results.StatusFailed},
+			}},
+		},
+		{
+			Target: Target{Name: "errored"},
+			Err:    errors.New("boom"),
+		},
+	}
+
+	m := BuildMatrix(outcomes)
+
+	sort.Strings(m.Clusters)
+	wantClusters := []string{"errored", "east", "west"}
+	sort.Strings(wantClusters)
+	if len(m.Clusters) != len(wantClusters) {
+		t.Fatalf("Clusters = %v, want %v", m.Clusters, wantClusters)
+	}
+
+	if m.Tests["t1"]["east"] != results.StatusPassed {
+		t.Errorf("Tests[t1][east] = %v, want %v", m.Tests["t1"]["east"], results.StatusPassed)
+	}
+	if m.Tests["t1"]["west"] != results.StatusFailed {
+		t.Errorf("Tests[t1][west] = %v, want %v", m.Tests["t1"]["west"], results.StatusFailed)
+	}
+	if _, ok := m.Tests["t1"]["errored"]; ok {
+		t.Errorf("expected no test entry for a target that errored before producing a report")
+	}
+}
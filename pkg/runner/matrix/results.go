@@ -0,0 +1,73 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package matrix
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"sigs.k8s.io/hydrophone/pkg/results"
+)
+
+// Matrix is the combined per-cluster-by-per-test view of a matrix run,
+// suitable for rendering or further processing by CI dashboards.
+type Matrix struct {
+	Clusters []string                             `json:"clusters"`
+	Tests    map[string]map[string]results.Status `json:"tests"`
+}
+
+// BuildMatrix indexes outcomes into a Matrix keyed by test name, then by
+// cluster name. Targets whose run errored before producing a report are
+// still listed under Clusters but contribute no test entries.
+func BuildMatrix(outcomes []ClusterResult) Matrix {
+	m := Matrix{
+		Tests: make(map[string]map[string]results.Status),
+	}
+
+	for _, outcome := range outcomes {
+		m.Clusters = append(m.Clusters, outcome.Target.Name)
+
+		if outcome.Report == nil {
+			continue
+		}
+
+		for _, test := range outcome.Report.Tests {
+			if m.Tests[test.Name] == nil {
+				m.Tests[test.Name] = make(map[string]results.Status)
+			}
+
+			m.Tests[test.Name][outcome.Target.Name] = test.Status
+		}
+	}
+
+	return m
+}
+
+// WriteJSON marshals m as indented JSON and writes it to path.
+func (m Matrix) WriteJSON(path string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to render results matrix: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write results matrix to %q: %w", path, err)
+	}
+
+	return nil
+}
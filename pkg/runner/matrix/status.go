@@ -0,0 +1,52 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package matrix
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+)
+
+// PrintStatusTable renders a compact per-cluster status table to stdout,
+// summarizing each target's pass/fail/skip counts and exit code.
+func PrintStatusTable(outcomes []ClusterResult) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	defer w.Flush()
+
+	fmt.Fprintln(w, "CLUSTER\tSTATUS\tPASSED\tFAILED\tSKIPPED\tEXIT CODE")
+
+	for _, outcome := range outcomes {
+		status := "ok"
+		var passed, failed, skipped int
+
+		switch {
+		case outcome.Err != nil:
+			status = fmt.Sprintf("error: %v", outcome.Err)
+		case outcome.ExitCode != 0:
+			status = "failed"
+		}
+
+		if outcome.Report != nil {
+			passed = outcome.Report.Passed
+			failed = outcome.Report.Failed
+			skipped = outcome.Report.Skipped
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\t%d\n", outcome.Target.Name, status, passed, failed, skipped, outcome.ExitCode)
+	}
+}
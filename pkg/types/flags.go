@@ -28,10 +28,17 @@ import (
 func (c *Configuration) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&c.configFile, "config", "", "path to an optional base configuration file.")
 	fs.StringVar(&c.Kubeconfig, "kubeconfig", c.Kubeconfig, "path to the kubeconfig file.")
+	fs.StringSliceVar(&c.Kubeconfigs, "kubeconfigs", c.Kubeconfigs, "path to an additional kubeconfig file to run the conformance suite against as part of a multi-cluster matrix run. may be repeated.")
+	fs.StringVar(&c.KubeconfigDir, "kubeconfig-dir", c.KubeconfigDir, "directory whose files are all treated as kubeconfigs for a multi-cluster matrix run, in addition to --kubeconfigs.")
+	fs.IntVar(&c.MaxConcurrentClusters, "max-concurrent-clusters", c.MaxConcurrentClusters, "maximum number of matrix clusters to run the conformance suite against concurrently.")
 	fs.IntVar(&c.Parallel, "parallel", c.Parallel, "number of parallel threads in test framework (automatically sets the --nodes Ginkgo flag).")
 	fs.IntVar(&c.Verbosity, "verbosity", c.Verbosity, "verbosity of test framework (values >= 6 automatically sets the -v Ginkgo flag).")
 	fs.StringVar(&c.OutputDir, "output-dir", c.OutputDir, "directory for logs.")
 	fs.StringVar(&c.Skip, "skip", c.Skip, "skip specific tests. allows regular expressions.")
+	fs.StringVar(&c.Focus, "focus", c.Focus, "focus on specific tests. allows regular expressions.")
+	fs.StringVar(&c.FocusFile, "focus-file", c.FocusFile, "file containing a newline-separated list of focus regular expressions.")
+	fs.StringVar(&c.SkipFile, "skip-file", c.SkipFile, "file containing a newline-separated list of skip regular expressions.")
+	fs.StringVar(&c.Suite, "suite", c.Suite, "name of a builtin test suite to run (e.g. 'quick-smoke', 'networking', 'storage').")
 	fs.StringVar(&c.ConformanceImage, "conformance-image", c.ConformanceImage, "specify a conformance container image of your choice.")
 	fs.StringVar(&c.BusyboxImage, "busybox-image", c.BusyboxImage, "specify an alternate busybox container image.")
 	fs.StringVar(&c.Namespace, "namespace", c.Namespace, "the namespace where the conformance pod is created.")
@@ -39,8 +46,14 @@ func (c *Configuration) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&c.TestRepoList, "test-repo-list", c.TestRepoList, "yaml file to override registries for test images.")
 	fs.StringVar(&c.TestRepo, "test-repo", c.TestRepo, "skip specific tests. allows regular expressions.")
 	fs.DurationVar(&c.StartupTimeout, "startup-timeout", c.StartupTimeout, "max time to wait for the conformance test pod to start up.")
+	fs.DurationVar(&c.LogReconnectTimeout, "log-reconnect-timeout", c.LogReconnectTimeout, "max total time to spend reconnecting a dropped log stream before giving up. 0 means retry forever.")
 	fs.StringSliceVar(&c.ExtraArgs, "extra-args", c.ExtraArgs, "Additional parameters to be provided to the conformance container. These parameters should be specified as key-value pairs, separated by commas. Each parameter should start with -- (e.g., --clean-start=true,--allowed-not-ready-nodes=2)")
 	fs.StringSliceVar(&c.ExtraGinkgoArgs, "extra-ginkgo-args", c.ExtraGinkgoArgs, "Additional parameters to be provided to Ginkgo runner. This flag has the same format as --extra-args.")
+	fs.StringVar(&c.OutputJUnitFile, "output-junit", c.OutputJUnitFile, "path to write a merged JUnit XML report to, relative to --output-dir.")
+	fs.StringVar(&c.OutputJSONFile, "output-json", c.OutputJSONFile, "path to write a normalized JSON test report to, relative to --output-dir.")
+	fs.StringVar(&c.Summary, "summary", c.Summary, "verbosity of the end-of-run summary printed to stdout. one of 'none', 'short' or 'full'.")
+	fs.DurationVar(&c.WatchInterval, "watch-interval", c.WatchInterval, "if set, keep hydrophone running and re-execute the conformance suite on this interval, writing each run's results under --output-dir/<timestamp>/.")
+	fs.StringVar(&c.NotifyWebhook, "notify-webhook", c.NotifyWebhook, "URL to POST a JSON payload to when a --watch-interval run detects regressions.")
 }
 
 func (c *Configuration) Complete(fs *pflag.FlagSet) (*Configuration, error) {
@@ -58,15 +71,28 @@ func (c *Configuration) Complete(fs *pflag.FlagSet) (*Configuration, error) {
 		overwrite(fs, "verbosity", &loaded.Verbosity, c.Verbosity)
 		overwrite(fs, "output-dir", &loaded.OutputDir, c.OutputDir)
 		overwrite(fs, "skip", &loaded.Skip, c.Skip)
+		overwrite(fs, "focus", &loaded.Focus, c.Focus)
+		overwrite(fs, "focus-file", &loaded.FocusFile, c.FocusFile)
+		overwrite(fs, "skip-file", &loaded.SkipFile, c.SkipFile)
+		overwrite(fs, "suite", &loaded.Suite, c.Suite)
 		overwrite(fs, "conformance-image", &loaded.ConformanceImage, c.ConformanceImage)
 		overwrite(fs, "busybox-image", &loaded.BusyboxImage, c.BusyboxImage)
 		overwrite(fs, "namespace", &loaded.Namespace, c.Namespace)
 		overwrite(fs, "dry-run", &loaded.DryRun, c.DryRun)
 		overwrite(fs, "startup-timeout", &loaded.StartupTimeout, c.StartupTimeout)
+		overwrite(fs, "log-reconnect-timeout", &loaded.LogReconnectTimeout, c.LogReconnectTimeout)
 		overwrite(fs, "test-repo-list", &loaded.TestRepoList, c.TestRepoList)
 		overwrite(fs, "test-repo", &loaded.TestRepo, c.TestRepo)
 		overwriteSlice(fs, "extra-args", &loaded.ExtraArgs, c.ExtraArgs)
 		overwriteSlice(fs, "extra-ginkgo-args", &loaded.ExtraGinkgoArgs, c.ExtraGinkgoArgs)
+		overwrite(fs, "output-junit", &loaded.OutputJUnitFile, c.OutputJUnitFile)
+		overwrite(fs, "output-json", &loaded.OutputJSONFile, c.OutputJSONFile)
+		overwrite(fs, "summary", &loaded.Summary, c.Summary)
+		overwriteSlice(fs, "kubeconfigs", &loaded.Kubeconfigs, c.Kubeconfigs)
+		overwrite(fs, "kubeconfig-dir", &loaded.KubeconfigDir, c.KubeconfigDir)
+		overwrite(fs, "max-concurrent-clusters", &loaded.MaxConcurrentClusters, c.MaxConcurrentClusters)
+		overwrite(fs, "watch-interval", &loaded.WatchInterval, c.WatchInterval)
+		overwrite(fs, "notify-webhook", &loaded.NotifyWebhook, c.NotifyWebhook)
 
 		result = loaded
 	}
@@ -96,6 +122,22 @@ func (c *Configuration) Complete(fs *pflag.FlagSet) (*Configuration, error) {
 		result.Kubeconfig = filepath.Join(homeDir, c.Kubeconfig[1:])
 	}
 
+	if err := result.applyTestSelection(); err != nil {
+		return nil, err
+	}
+
+	switch result.Summary {
+	case "", "none", "short", "full":
+	default:
+		return nil, fmt.Errorf("invalid --summary value %q: must be one of 'none', 'short' or 'full'", result.Summary)
+	}
+
+	resolvedKubeconfigs, err := result.resolveMatrixKubeconfigs()
+	if err != nil {
+		return nil, err
+	}
+	result.ResolvedKubeconfigs = resolvedKubeconfigs
+
 	if err := result.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid configuration: %w", err)
 	}
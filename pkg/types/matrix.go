@@ -0,0 +1,104 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// resolveMatrixKubeconfigs expands --kubeconfigs and --kubeconfig-dir into
+// the deduplicated, sorted list of kubeconfig paths a matrix run should
+// target, validating that every one of them exists and that none of them
+// would derive a colliding matrix target name. An empty result means the
+// caller should fall back to the single-cluster --kubeconfig flow.
+func (c *Configuration) resolveMatrixKubeconfigs() ([]string, error) {
+	paths := append([]string{}, c.Kubeconfigs...)
+
+	if c.KubeconfigDir != "" {
+		entries, err := os.ReadDir(c.KubeconfigDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read --kubeconfig-dir %q: %w", c.KubeconfigDir, err)
+		}
+
+		for _, entry := range entries {
+			if entry.IsDir() {
+				continue
+			}
+
+			paths = append(paths, filepath.Join(c.KubeconfigDir, entry.Name()))
+		}
+	}
+
+	if len(paths) == 0 {
+		return nil, nil
+	}
+
+	paths = mergeUnique(nil, paths...)
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		if _, err := os.Stat(path); err != nil {
+			return nil, fmt.Errorf("invalid kubeconfig %q: %w", path, err)
+		}
+	}
+
+	if err := rejectDuplicateMatrixTargetNames(paths); err != nil {
+		return nil, err
+	}
+
+	return paths, nil
+}
+
+// rejectDuplicateMatrixTargetNames returns an error if two paths would
+// derive the same matrix target name, which would collapse their supposedly
+// isolated namespaces into one and make them indistinguishable in the
+// results matrix and status table.
+func rejectDuplicateMatrixTargetNames(paths []string) error {
+	seenBy := make(map[string]string, len(paths))
+
+	for _, path := range paths {
+		name := matrixTargetName(path)
+
+		if other, ok := seenBy[name]; ok {
+			return fmt.Errorf("kubeconfigs %q and %q both derive the matrix target name %q; rename one of the files so they don't collide", other, path, name)
+		}
+
+		seenBy[name] = path
+	}
+
+	return nil
+}
+
+// matrixTargetName mirrors the naming pkg/runner/matrix uses to build
+// Targets, so collisions can be rejected here before any target is built.
+// It combines the parent directory and file name (without extension) so
+// sibling kubeconfigs that share a file name, e.g. clusters/east/config and
+// clusters/west/config, still derive distinct names.
+func matrixTargetName(kubeconfig string) string {
+	base := strings.TrimSuffix(filepath.Base(kubeconfig), filepath.Ext(kubeconfig))
+	dir := filepath.Base(filepath.Dir(kubeconfig))
+
+	if dir == "" || dir == "." || dir == string(filepath.Separator) {
+		return base
+	}
+
+	return dir + "-" + base
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func writeKubeconfig(t *testing.T, dir, name string) string {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte("kind: Config\n"), 0o644); err != nil {
+		t.Fatalf("failed to write fake kubeconfig %q: %v", path, err)
+	}
+
+	return path
+}
+
+func TestResolveMatrixKubeconfigsEmpty(t *testing.T) {
+	c := &Configuration{}
+
+	paths, err := c.resolveMatrixKubeconfigs()
+	if err != nil {
+		t.Fatalf("resolveMatrixKubeconfigs returned an error: %v", err)
+	}
+	if paths != nil {
+		t.Errorf("paths = %v, want nil when no matrix flags are set", paths)
+	}
+}
+
+func TestResolveMatrixKubeconfigsFromDirAndFlags(t *testing.T) {
+	dir := t.TempDir()
+	a := writeKubeconfig(t, dir, "a.yaml")
+	b := writeKubeconfig(t, dir, "b.yaml")
+
+	extraDir := t.TempDir()
+	extra := writeKubeconfig(t, extraDir, "extra.yaml")
+
+	c := &Configuration{
+		KubeconfigDir: dir,
+		Kubeconfigs:   []string{extra, a}, // duplicate "a" on purpose
+	}
+
+	paths, err := c.resolveMatrixKubeconfigs()
+	if err != nil {
+		t.Fatalf("resolveMatrixKubeconfigs returned an error: %v", err)
+	}
+
+	want := []string{a, b, extra}
+	for i := range want {
+		want[i] = filepath.Clean(want[i])
+	}
+	got := append([]string{}, paths...)
+	for i := range got {
+		got[i] = filepath.Clean(got[i])
+	}
+
+	sort.Strings(got)
+	sort.Strings(want)
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("paths = %v, want %v", got, want)
+	}
+}
+
+func TestResolveMatrixKubeconfigsMissingFile(t *testing.T) {
+	c := &Configuration{Kubeconfigs: []string{filepath.Join(t.TempDir(), "does-not-exist")}}
+
+	if _, err := c.resolveMatrixKubeconfigs(); err == nil {
+		t.Fatal("expected an error for a kubeconfig that does not exist")
+	}
+}
+
+func TestResolveMatrixKubeconfigsRejectsNameCollision(t *testing.T) {
+	// matrixTargetName combines the parent directory's basename with the
+	// file's basename, so two kubeconfigs collide only when *both* match:
+	// here both live in a directory named "prod" and are both called
+	// "config", so both derive the target name "prod-config".
+	firstDir := filepath.Join(t.TempDir(), "prod")
+	secondDir := filepath.Join(t.TempDir(), "prod")
+	if err := os.MkdirAll(firstDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(secondDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	first := writeKubeconfig(t, firstDir, "config")
+	second := writeKubeconfig(t, secondDir, "config")
+
+	if got := matrixTargetName(first); got != matrixTargetName(second) {
+		t.Fatalf("test fixture is broken: %q and %q derive different names (%q vs %q)", first, second, got, matrixTargetName(second))
+	}
+
+	c := &Configuration{Kubeconfigs: []string{first, second}}
+
+	if _, err := c.resolveMatrixKubeconfigs(); err == nil {
+		t.Fatal("expected an error when two kubeconfigs derive the same matrix target name")
+	}
+}
+
+func TestMatrixTargetName(t *testing.T) {
+	tests := []struct {
+		kubeconfig string
+		want       string
+	}{
+		{"clusters/east/config", "east-config"},
+		{"clusters/west/config.yaml", "west-config"},
+		{"config", "config"},
+		{"/config", "config"},
+	}
+
+	for _, tt := range tests {
+		if got := matrixTargetName(tt.kubeconfig); got != tt.want {
+			t.Errorf("matrixTargetName(%q) = %q, want %q", tt.kubeconfig, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+//go:embed suites.yaml
+var builtinSuitesYAML []byte
+
+// SuiteDefinition describes a named, reusable combination of focus/skip
+// patterns and extra arguments, as selected with the --suite flag.
+type SuiteDefinition struct {
+	Focus           []string `json:"focus,omitempty"`
+	Skip            []string `json:"skip,omitempty"`
+	ExtraArgs       []string `json:"extraArgs,omitempty"`
+	ExtraGinkgoArgs []string `json:"extraGinkgoArgs,omitempty"`
+}
+
+// resolveSuite looks up name in the builtin suite catalog.
+func resolveSuite(name string) (SuiteDefinition, error) {
+	suites := map[string]SuiteDefinition{}
+	if err := yaml.Unmarshal(builtinSuitesYAML, &suites); err != nil {
+		return SuiteDefinition{}, fmt.Errorf("failed to parse builtin suite catalog: %w", err)
+	}
+
+	suite, ok := suites[name]
+	if !ok {
+		return SuiteDefinition{}, fmt.Errorf("unknown suite %q", name)
+	}
+
+	return suite, nil
+}
+
+// loadPatternFile reads a newline-delimited list of regular expressions from
+// path, as used by --focus-file and --skip-file. Blank lines and lines
+// starting with '#' are ignored.
+func loadPatternFile(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %q: %w", path, err)
+	}
+
+	var patterns []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		patterns = append(patterns, line)
+	}
+
+	return patterns, nil
+}
+
+// applyTestSelection resolves --suite, --focus-file and --skip-file into
+// --ginkgo.focus/--ginkgo.skip entries in ExtraGinkgoArgs, merging them with
+// c.Focus/c.Skip and anything the user already passed via
+// --extra-ginkgo-args.
+func (c *Configuration) applyTestSelection() error {
+	var focusPatterns, skipPatterns []string
+
+	if c.Suite != "" {
+		suite, err := resolveSuite(c.Suite)
+		if err != nil {
+			return fmt.Errorf("invalid --suite: %w", err)
+		}
+
+		focusPatterns = append(focusPatterns, suite.Focus...)
+		skipPatterns = append(skipPatterns, suite.Skip...)
+		c.ExtraArgs = mergeUnique(c.ExtraArgs, suite.ExtraArgs...)
+		c.ExtraGinkgoArgs = mergeUnique(c.ExtraGinkgoArgs, suite.ExtraGinkgoArgs...)
+	}
+
+	if c.Focus != "" {
+		focusPatterns = append(focusPatterns, c.Focus)
+	}
+
+	if c.FocusFile != "" {
+		patterns, err := loadPatternFile(c.FocusFile)
+		if err != nil {
+			return fmt.Errorf("invalid --focus-file: %w", err)
+		}
+
+		focusPatterns = append(focusPatterns, patterns...)
+	}
+
+	if c.Skip != "" {
+		skipPatterns = append(skipPatterns, c.Skip)
+	}
+
+	if c.SkipFile != "" {
+		patterns, err := loadPatternFile(c.SkipFile)
+		if err != nil {
+			return fmt.Errorf("invalid --skip-file: %w", err)
+		}
+
+		skipPatterns = append(skipPatterns, patterns...)
+	}
+
+	if focus := combinePatterns(focusPatterns); focus != "" && !hasGinkgoArg(c.ExtraGinkgoArgs, "--ginkgo.focus=") {
+		c.ExtraGinkgoArgs = append(c.ExtraGinkgoArgs, "--ginkgo.focus="+focus)
+	}
+
+	if skip := combinePatterns(skipPatterns); skip != "" && !hasGinkgoArg(c.ExtraGinkgoArgs, "--ginkgo.skip=") {
+		c.ExtraGinkgoArgs = append(c.ExtraGinkgoArgs, "--ginkgo.skip="+skip)
+	}
+
+	return nil
+}
+
+// combinePatterns ORs a set of regular expressions together into one.
+func combinePatterns(patterns []string) string {
+	return strings.Join(patterns, "|")
+}
+
+// hasGinkgoArg reports whether args already contains an entry starting with
+// prefix, so explicit --extra-ginkgo-args always win over derived ones.
+func hasGinkgoArg(args []string, prefix string) bool {
+	for _, arg := range args {
+		if strings.HasPrefix(arg, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// mergeUnique appends the entries of additions to dst that are not already
+// present in it.
+func mergeUnique(dst []string, additions ...string) []string {
+	for _, addition := range additions {
+		found := false
+		for _, existing := range dst {
+			if existing == addition {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			dst = append(dst, addition)
+		}
+	}
+
+	return dst
+}
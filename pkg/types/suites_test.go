@@ -0,0 +1,145 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestResolveSuite(t *testing.T) {
+	suite, err := resolveSuite("networking")
+	if err != nil {
+		t.Fatalf("resolveSuite returned an error: %v", err)
+	}
+
+	if want := []string{`\[sig-network\]`}; !reflect.DeepEqual(suite.Focus, want) {
+		t.Errorf("Focus = %v, want %v", suite.Focus, want)
+	}
+
+	if _, err := resolveSuite("does-not-exist"); err == nil {
+		t.Fatal("expected an error for an unknown suite, got nil")
+	}
+}
+
+func TestLoadPatternFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "patterns.txt")
+	contents := "\\[sig-network\\]\n\n# a comment\n\\[sig-storage\\]\n  \n"
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("failed to write pattern file: %v", err)
+	}
+
+	patterns, err := loadPatternFile(path)
+	if err != nil {
+		t.Fatalf("loadPatternFile returned an error: %v", err)
+	}
+
+	want := []string{`\[sig-network\]`, `\[sig-storage\]`}
+	if !reflect.DeepEqual(patterns, want) {
+		t.Errorf("patterns = %v, want %v", patterns, want)
+	}
+}
+
+func TestMergeUnique(t *testing.T) {
+	got := mergeUnique([]string{"a", "b"}, "b", "c")
+	want := []string{"a", "b", "c"}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("mergeUnique = %v, want %v", got, want)
+	}
+}
+
+func TestCombinePatterns(t *testing.T) {
+	if got, want := combinePatterns([]string{"a", "b"}), "a|b"; got != want {
+		t.Errorf("combinePatterns = %q, want %q", got, want)
+	}
+
+	if got, want := combinePatterns(nil), ""; got != want {
+		t.Errorf("combinePatterns(nil) = %q, want %q", got, want)
+	}
+}
+
+func TestHasGinkgoArg(t *testing.T) {
+	args := []string{"--ginkgo.focus=foo"}
+
+	if !hasGinkgoArg(args, "--ginkgo.focus=") {
+		t.Error("expected --ginkgo.focus= to be detected")
+	}
+	if hasGinkgoArg(args, "--ginkgo.skip=") {
+		t.Error("did not expect --ginkgo.skip= to be detected")
+	}
+}
+
+func TestApplyTestSelectionMergesSuiteAndExplicitFlags(t *testing.T) {
+	c := &Configuration{
+		Suite: "networking",
+		Focus: `\[MyExtra\]`,
+	}
+
+	if err := c.applyTestSelection(); err != nil {
+		t.Fatalf("applyTestSelection returned an error: %v", err)
+	}
+
+	want := []string{`--ginkgo.focus=\[sig-network\]|\[MyExtra\]`}
+	if !reflect.DeepEqual(c.ExtraGinkgoArgs, want) {
+		t.Errorf("ExtraGinkgoArgs = %v, want %v", c.ExtraGinkgoArgs, want)
+	}
+}
+
+func TestApplyTestSelectionExplicitGinkgoArgsWin(t *testing.T) {
+	c := &Configuration{
+		Focus:           `\[MyExtra\]`,
+		ExtraGinkgoArgs: []string{"--ginkgo.focus=already-set"},
+	}
+
+	if err := c.applyTestSelection(); err != nil {
+		t.Fatalf("applyTestSelection returned an error: %v", err)
+	}
+
+	want := []string{"--ginkgo.focus=already-set"}
+	if !reflect.DeepEqual(c.ExtraGinkgoArgs, want) {
+		t.Errorf("ExtraGinkgoArgs = %v, want %v (explicit --extra-ginkgo-args should win)", c.ExtraGinkgoArgs, want)
+	}
+}
+
+func TestApplyTestSelectionUnknownSuite(t *testing.T) {
+	c := &Configuration{Suite: "does-not-exist"}
+
+	if err := c.applyTestSelection(); err == nil {
+		t.Fatal("expected an error for an unknown --suite, got nil")
+	}
+}
+
+func TestApplyTestSelectionFocusFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "focus.txt")
+	if err := os.WriteFile(path, []byte(`\[sig-network\]`+"\n"), 0o644); err != nil {
+		t.Fatalf("failed to write focus file: %v", err)
+	}
+
+	c := &Configuration{FocusFile: path}
+
+	if err := c.applyTestSelection(); err != nil {
+		t.Fatalf("applyTestSelection returned an error: %v", err)
+	}
+
+	want := []string{`--ginkgo.focus=\[sig-network\]`}
+	if !reflect.DeepEqual(c.ExtraGinkgoArgs, want) {
+		t.Errorf("ExtraGinkgoArgs = %v, want %v", c.ExtraGinkgoArgs, want)
+	}
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package watch
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"sigs.k8s.io/hydrophone/pkg/log"
+	"sigs.k8s.io/hydrophone/pkg/results"
+)
+
+// Diff is the result of comparing two consecutive conformance runs.
+type Diff struct {
+	// Regressions are tests that passed in the previous run but failed in
+	// this one.
+	Regressions []string `json:"regressions"`
+	// Recoveries are tests that failed in the previous run but passed in
+	// this one.
+	Recoveries []string `json:"recoveries"`
+}
+
+// diffReports compares previous against current and returns the tests that
+// flipped from passed to failed (regressions) or failed to passed
+// (recoveries). previous may be nil, in which case there is nothing to
+// compare against.
+func diffReports(previous *results.Report, current *results.Report) Diff {
+	var diff Diff
+
+	if previous == nil {
+		return diff
+	}
+
+	previousStatus := make(map[string]results.Status, len(previous.Tests))
+	for _, test := range previous.Tests {
+		previousStatus[test.Name] = test.Status
+	}
+
+	for _, test := range current.Tests {
+		prior, ok := previousStatus[test.Name]
+		if !ok {
+			continue
+		}
+
+		switch {
+		case prior == results.StatusPassed && test.Status == results.StatusFailed:
+			diff.Regressions = append(diff.Regressions, test.Name)
+		case prior == results.StatusFailed && test.Status == results.StatusPassed:
+			diff.Recoveries = append(diff.Recoveries, test.Name)
+		}
+	}
+
+	return diff
+}
+
+// writeDiff persists diff as regressions.json inside runDir.
+func writeDiff(runDir string, diff Diff) error {
+	data, err := json.MarshalIndent(diff, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to render regressions report: %w", err)
+	}
+
+	path := filepath.Join(runDir, "regressions.json")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write regressions report to %q: %w", path, err)
+	}
+
+	return nil
+}
+
+// printDiff logs a short summary of diff to stdout.
+func printDiff(diff Diff) {
+	if len(diff.Regressions) == 0 && len(diff.Recoveries) == 0 {
+		log.Println("no conformance drift detected since the previous run")
+		return
+	}
+
+	if len(diff.Regressions) > 0 {
+		log.Printf("regressions (%d): %v", len(diff.Regressions), diff.Regressions)
+	}
+
+	if len(diff.Recoveries) > 0 {
+		log.Printf("recoveries (%d): %v", len(diff.Recoveries), diff.Recoveries)
+	}
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package watch
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"sigs.k8s.io/hydrophone/pkg/results"
+)
+
+func TestDiffReportsNilPrevious(t *testing.T) {
+	current := &results.Report{
+		Tests: []results.TestResult{{Name: "a", Status: results.StatusFailed}},
+	}
+
+	diff := diffReports(nil, current)
+
+	if len(diff.Regressions) != 0 || len(diff.Recoveries) != 0 {
+		t.Fatalf("expected no diff against a nil previous report, got %+v", diff)
+	}
+}
+
+func TestDiffReportsRegressionsAndRecoveries(t *testing.T) {
+	previous := &results.Report{
+		Tests: []results.TestResult{
+			{Name: "a", Status: results.StatusPassed},
+			{Name: "b", Status: results.StatusFailed},
+			{Name: "c", Status: results.StatusPassed},
+		},
+	}
+	current := &results.Report{
+		Tests: []results.TestResult{
+			{Name: "a", Status: results.StatusFailed},
+			{Name: "b", Status: results.StatusPassed},
+			{Name: "c", Status: results.StatusPassed},
+		},
+	}
+
+	diff := diffReports(previous, current)
+
+	if want := []string{"a"}; !reflect.DeepEqual(diff.Regressions, want) {
+		t.Errorf("Regressions = %v, want %v", diff.Regressions, want)
+	}
+	if want := []string{"b"}; !reflect.DeepEqual(diff.Recoveries, want) {
+		t.Errorf("Recoveries = %v, want %v", diff.Recoveries, want)
+	}
+}
+
+func TestDiffReportsIgnoresUnseenTests(t *testing.T) {
+	previous := &results.Report{
+		Tests: []results.TestResult{{Name: "a", Status: results.StatusPassed}},
+	}
+	current := &results.Report{
+		Tests: []results.TestResult{{Name: "new-test", Status: results.StatusFailed}},
+	}
+
+	diff := diffReports(previous, current)
+
+	if len(diff.Regressions) != 0 || len(diff.Recoveries) != 0 {
+		t.Fatalf("expected tests absent from the previous run to be ignored, got %+v", diff)
+	}
+}
+
+func TestWriteDiff(t *testing.T) {
+	dir := t.TempDir()
+	diff := Diff{Regressions: []string{"a"}, Recoveries: []string{"b"}}
+
+	if err := writeDiff(dir, diff); err != nil {
+		t.Fatalf("writeDiff returned an error: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "regressions.json")); err != nil {
+		t.Fatalf("expected regressions.json to be written: %v", err)
+	}
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package watch
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"sigs.k8s.io/hydrophone/pkg/results"
+)
+
+// RunEntry is a single entry in history.json, summarizing one watch run.
+type RunEntry struct {
+	Timestamp time.Time `json:"timestamp"`
+	Dir       string    `json:"dir"`
+	Total     int       `json:"total"`
+	Passed    int       `json:"passed"`
+	Failed    int       `json:"failed"`
+	Skipped   int       `json:"skipped"`
+}
+
+// Index is the rolling history of watch runs, persisted as history.json in
+// the watch output directory.
+type Index struct {
+	Runs []RunEntry `json:"runs"`
+}
+
+const historyFileName = "history.json"
+
+// loadIndex reads history.json from outputDir. A missing file is treated as
+// an empty index rather than an error, since the first watch run has no
+// history yet.
+func loadIndex(outputDir string) (*Index, error) {
+	data, err := os.ReadFile(filepath.Join(outputDir, historyFileName))
+	if os.IsNotExist(err) {
+		return &Index{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", historyFileName, err)
+	}
+
+	var index Index
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", historyFileName, err)
+	}
+
+	return &index, nil
+}
+
+// save writes the index back to outputDir/history.json.
+func (i *Index) save(outputDir string) error {
+	data, err := json.MarshalIndent(i, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to render %s: %w", historyFileName, err)
+	}
+
+	path := filepath.Join(outputDir, historyFileName)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// append records a completed run.
+func (i *Index) append(entry RunEntry) {
+	i.Runs = append(i.Runs, entry)
+}
+
+// latest loads and returns the report from the most recent run in the
+// index, or nil if the index is empty.
+func (i *Index) latest() *results.Report {
+	if len(i.Runs) == 0 {
+		return nil
+	}
+
+	last := i.Runs[len(i.Runs)-1]
+
+	data, err := os.ReadFile(filepath.Join(last.Dir, "report.json"))
+	if err != nil {
+		return nil
+	}
+
+	var report results.Report
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil
+	}
+
+	return &report
+}
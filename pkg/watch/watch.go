@@ -0,0 +1,165 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package watch implements scheduled, recurring conformance runs and
+// compares consecutive runs to detect conformance drift on long-lived
+// clusters.
+package watch
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"sigs.k8s.io/hydrophone/pkg/log"
+	"sigs.k8s.io/hydrophone/pkg/results"
+)
+
+// timestampLayout is used for the per-run output directory name. The
+// RunEntry timestamps in history.json are encoded by encoding/json instead.
+const timestampLayout = "20060102T150405Z"
+
+// RunFunc executes a single conformance run and returns its normalized
+// results.
+type RunFunc func(ctx context.Context) (*results.Report, error)
+
+// Options configures a watch loop.
+type Options struct {
+	// Interval between the end of one run and the start of the next. A
+	// value <= 0 means run exactly once.
+	Interval time.Duration
+	// OutputDir is the root directory each run's <timestamp>/ subdirectory
+	// is created under.
+	OutputDir string
+	// NotifyWebhook, if set, is POSTed a JSON payload whenever a run
+	// detects regressions.
+	NotifyWebhook string
+	// ClusterVersion is included in the webhook payload for context.
+	ClusterVersion string
+}
+
+// Run executes runFunc, records its results under opts.OutputDir and, if
+// opts.Interval > 0, repeats on that interval until ctx is cancelled.
+// Between runs it diffs outcomes against the previous run and reports
+// regressions and recoveries. A failed run does not end the schedule: it is
+// logged and the loop continues to the next interval, since a long-lived
+// watch is expected to ride out transient failures (a flaky API call, a
+// dropped webhook) rather than stop detecting drift over them. Running
+// once (opts.Interval <= 0) still returns the run's error, since there is
+// no next interval to fall back on.
+func Run(ctx context.Context, opts Options, runFunc RunFunc) error {
+	for {
+		if err := runOnce(ctx, opts, runFunc); err != nil {
+			if opts.Interval <= 0 || ctx.Err() != nil {
+				return err
+			}
+
+			log.Printf("conformance run failed, continuing with the schedule: %v", err)
+		}
+
+		if opts.Interval <= 0 {
+			return nil
+		}
+
+		log.Printf("next conformance run scheduled in %s", opts.Interval)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(opts.Interval):
+		}
+	}
+}
+
+func runOnce(ctx context.Context, opts Options, runFunc RunFunc) error {
+	report, err := runFunc(ctx)
+	if err != nil {
+		return fmt.Errorf("conformance run failed: %w", err)
+	}
+
+	runDir := filepath.Join(opts.OutputDir, report.GeneratedAt.UTC().Format(timestampLayout))
+	if err := os.MkdirAll(runDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create run directory %q: %w", runDir, err)
+	}
+
+	reportFile := filepath.Join(runDir, "report.json")
+	if err := results.WriteJSONReport(report, reportFile); err != nil {
+		return err
+	}
+
+	index, err := loadIndex(opts.OutputDir)
+	if err != nil {
+		return err
+	}
+
+	previous := index.latest()
+
+	diff := diffReports(previous, report)
+	if err := writeDiff(runDir, diff); err != nil {
+		return err
+	}
+
+	printDiff(diff)
+
+	if len(diff.Regressions) > 0 && opts.NotifyWebhook != "" {
+		if err := notifyWebhook(ctx, opts.NotifyWebhook, webhookPayload{
+			ClusterVersion: opts.ClusterVersion,
+			Regressions:    diff.Regressions,
+			OutputDir:      runDir,
+		}); err != nil {
+			log.Printf("failed to notify webhook: %v", err)
+		}
+	}
+
+	index.append(RunEntry{
+		Timestamp: report.GeneratedAt,
+		Dir:       runDir,
+		Total:     report.Total,
+		Passed:    report.Passed,
+		Failed:    report.Failed,
+		Skipped:   report.Skipped,
+	})
+
+	if err := index.save(opts.OutputDir); err != nil {
+		return err
+	}
+
+	if err := updateLatestSymlink(opts.OutputDir, runDir); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// updateLatestSymlink re-points <outputDir>/latest.json at runDir/report.json.
+func updateLatestSymlink(outputDir, runDir string) error {
+	link := filepath.Join(outputDir, "latest.json")
+
+	_ = os.Remove(link)
+
+	target, err := filepath.Rel(outputDir, filepath.Join(runDir, "report.json"))
+	if err != nil {
+		target = filepath.Join(runDir, "report.json")
+	}
+
+	if err := os.Symlink(target, link); err != nil {
+		return fmt.Errorf("failed to update latest.json symlink: %w", err)
+	}
+
+	return nil
+}
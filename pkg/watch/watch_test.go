@@ -0,0 +1,83 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package watch
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"sigs.k8s.io/hydrophone/pkg/results"
+)
+
+// TestRunContinuesAfterFailedRun verifies that a failing run is logged and
+// the schedule keeps going, rather than Run returning (and ending the watch
+// loop) on the very first failure.
+func TestRunContinuesAfterFailedRun(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var calls int32
+
+	runFunc := func(ctx context.Context) (*results.Report, error) {
+		n := atomic.AddInt32(&calls, 1)
+		if n == 1 {
+			return nil, errors.New("transient failure")
+		}
+
+		if n == 2 {
+			// Stop the schedule once we've proven a run happened after the
+			// first one failed.
+			cancel()
+		}
+
+		return &results.Report{GeneratedAt: time.Now()}, nil
+	}
+
+	opts := Options{
+		Interval:  time.Millisecond,
+		OutputDir: t.TempDir(),
+	}
+
+	err := Run(ctx, opts, runFunc)
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Run error = %v, want context.Canceled", err)
+	}
+	if got := atomic.LoadInt32(&calls); got < 2 {
+		t.Fatalf("runFunc was called %d time(s), want at least 2 (the loop should continue past the first failure)", got)
+	}
+}
+
+// TestRunStopsOnFailureWithoutInterval verifies that a one-shot run
+// (Interval <= 0) still surfaces the run's error, since there is no next
+// interval to fall back on.
+func TestRunStopsOnFailureWithoutInterval(t *testing.T) {
+	wantErr := errors.New("boom")
+
+	runFunc := func(ctx context.Context) (*results.Report, error) {
+		return nil, wantErr
+	}
+
+	err := Run(context.Background(), Options{OutputDir: t.TempDir()}, runFunc)
+
+	if err == nil || !errors.Is(err, wantErr) {
+		t.Errorf("Run error = %v, want it to wrap %v", err, wantErr)
+	}
+}